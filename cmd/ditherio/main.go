@@ -0,0 +1,244 @@
+// Command ditherio dithers an image file using one of the algorithms and
+// palettes provided by the dither package.
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"image"
+	"image/color"
+	"image/color/palette"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/MatiasLyyra/ditherio/dither"
+)
+
+func main() {
+	var (
+		inPath     = flag.String("in", "", "path to the source image (required)")
+		outPath    = flag.String("out", "", "path to write the dithered image to (required)")
+		algoName   = flag.String("algo", "floyd", "dithering algorithm: floyd|burkes|jarvis|stucki|atkinson|bayer4|bayer8")
+		paletteArg = flag.String("palette", "bw", "palette: bw|websafe|plan9|mediancut:N|file:palette.hex")
+		serpentine = flag.Bool("serpentine", false, "use serpentine scan order (error-diffusion algorithms only)")
+		format     = flag.String("format", "png", "output format: png|gif|jpeg")
+		stream     = flag.Bool("stream", false, "dither with dither.Stream instead of -format, writing a bounded-memory raw 8-bit RGB stream to -out (requires an error-diffusion -algo)")
+	)
+	flag.Parse()
+
+	if *inPath == "" || *outPath == "" {
+		fmt.Fprintln(os.Stderr, "ditherio: -in and -out are required")
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	img, err := decodeImage(*inPath)
+	if err != nil {
+		log.Fatalf("ditherio: %s\n", err)
+	}
+
+	palette, find, err := resolvePalette(*paletteArg, img)
+	if err != nil {
+		log.Fatalf("ditherio: %s\n", err)
+	}
+
+	if *stream {
+		kernel, ok := diffusionKernels[*algoName]
+		if !ok {
+			log.Fatalf("ditherio: -stream requires an error-diffusion -algo, got %q\n", *algoName)
+		}
+		if err := streamDither(*outPath, img, kernel, find, *serpentine); err != nil {
+			log.Fatalf("ditherio: %s\n", err)
+		}
+		return
+	}
+
+	out, err := applyAlgo(*algoName, img, find, *serpentine)
+	if err != nil {
+		log.Fatalf("ditherio: %s\n", err)
+	}
+
+	if err := encodeImage(*outPath, *format, out, palette); err != nil {
+		log.Fatalf("ditherio: %s\n", err)
+	}
+}
+
+// streamDither dithers img row-by-row through a dither.Stream, keeping only
+// the kernel's error accumulator in memory rather than the whole dithered
+// image, and writes the resulting raw 8-bit RGB stream straight to outPath.
+func streamDither(outPath string, img image.Image, k dither.DiffusionKernel, find dither.ColorFinder, serpentine bool) error {
+	f, err := os.Create(outPath)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", outPath, err)
+	}
+	defer f.Close()
+
+	rect := img.Bounds()
+	s := dither.NewStream(f, k, find, rect.Dx(), rect.Dy(), dither.StreamOpts{Serpentine: serpentine})
+	row := make([]color.Color, rect.Dx())
+	for y := rect.Min.Y; y < rect.Max.Y; y++ {
+		for x := rect.Min.X; x < rect.Max.X; x++ {
+			row[x-rect.Min.X] = img.At(x, y)
+		}
+		if err := s.Feed(row); err != nil {
+			return fmt.Errorf("failed to feed row %d: %w", y, err)
+		}
+	}
+	return s.Close()
+}
+
+func decodeImage(path string) (image.Image, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer f.Close()
+	img, _, err := image.Decode(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", path, err)
+	}
+	return img, nil
+}
+
+// resolvePalette turns -palette into the color.Palette it's drawn from (used
+// for GIF output) and the ColorFinder used to quantize pixels.
+func resolvePalette(arg string, img image.Image) (color.Palette, dither.ColorFinder, error) {
+	switch {
+	case arg == "bw":
+		return color.Palette{color.Gray16{0}, color.Gray16{0xFFFF}}, dither.BlackAndWhitePalette, nil
+	case arg == "websafe":
+		return palette.WebSafe, dither.WebSafePalette, nil
+	case arg == "plan9":
+		return palette.Plan9, dither.Plan9Palette, nil
+	case strings.HasPrefix(arg, "mediancut:"):
+		n, err := strconv.Atoi(strings.TrimPrefix(arg, "mediancut:"))
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid -palette mediancut count: %w", err)
+		}
+		p := dither.MedianCut(img, n)
+		return p, dither.PaletteFinder(p), nil
+	case strings.HasPrefix(arg, "file:"):
+		p, err := loadHexPalette(strings.TrimPrefix(arg, "file:"))
+		if err != nil {
+			return nil, nil, err
+		}
+		return p, dither.PaletteFinder(p), nil
+	default:
+		return nil, nil, fmt.Errorf("unknown -palette %q", arg)
+	}
+}
+
+// loadHexPalette reads a palette file with one "#RRGGBB" (or "RRGGBB") color
+// per line. Blank lines are skipped and a leading "#" is stripped before the
+// hex value is parsed; there is no comment syntax, so any other line that is
+// not a hex color is an error.
+func loadHexPalette(path string) (color.Palette, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open palette file %s: %w", path, err)
+	}
+	defer f.Close()
+
+	var p color.Palette
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		line = strings.TrimPrefix(line, "#")
+		v, err := strconv.ParseUint(line, 16, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid color %q in %s: %w", line, path, err)
+		}
+		p = append(p, color.NRGBA{
+			R: uint8(v >> 16),
+			G: uint8(v >> 8),
+			B: uint8(v),
+			A: 0xFF,
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read palette file %s: %w", path, err)
+	}
+	if len(p) == 0 {
+		return nil, fmt.Errorf("palette file %s has no colors", path)
+	}
+	return p, nil
+}
+
+// diffusionKernels maps -algo names to their DiffusionKernel, for the
+// algorithms that support -serpentine.
+var diffusionKernels = map[string]dither.DiffusionKernel{
+	"floyd":    dither.FloydSteinbergKernel,
+	"burkes":   dither.BurkesKernel,
+	"jarvis":   dither.JarvisKernel,
+	"stucki":   dither.StuckiKernel,
+	"atkinson": dither.AtkinsonKernel,
+}
+
+// applyAlgo dithers img with the named algorithm. Serpentine scanning needs
+// the DiffusionKernel directly (to mirror its offsets), so it's handled
+// before falling back to the uniform dither.Drawer dispatch that covers
+// every other algorithm, diffusion or ordered alike.
+func applyAlgo(name string, img image.Image, find dither.ColorFinder, serpentine bool) (image.Image, error) {
+	if serpentine {
+		kernel, ok := diffusionKernels[name]
+		if !ok {
+			return nil, fmt.Errorf("-serpentine requires an error-diffusion -algo, got %q", name)
+		}
+		return dither.DitherSerpentine(img, kernel, find), nil
+	}
+
+	drawers := map[string]dither.Drawer{
+		"bayer4": dither.OrderedDrawer{Matrix: dither.Bayer4},
+		"bayer8": dither.OrderedDrawer{Matrix: dither.Bayer8},
+	}
+	for name, kernel := range diffusionKernels {
+		drawers[name] = dither.DiffusionDrawer{Algo: dither.NewDiffusionAlgo(kernel)}
+	}
+
+	d, ok := drawers[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown -algo %q", name)
+	}
+	return d.Draw(img, find), nil
+}
+
+func encodeImage(path, format string, img image.Image, p color.Palette) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	defer f.Close()
+
+	switch format {
+	case "png":
+		return png.Encode(f, img)
+	case "jpeg":
+		return jpeg.Encode(f, img, nil)
+	case "gif":
+		return gif.Encode(f, toPaletted(img, p), nil)
+	default:
+		return fmt.Errorf("unknown -format %q", format)
+	}
+}
+
+// toPaletted converts img into an *image.Paletted backed by p, mapping each
+// pixel to its index in p.
+func toPaletted(img image.Image, p color.Palette) *image.Paletted {
+	rect := img.Bounds()
+	out := image.NewPaletted(rect, p)
+	for y := rect.Min.Y; y < rect.Max.Y; y++ {
+		for x := rect.Min.X; x < rect.Max.X; x++ {
+			out.Set(x, y, img.At(x, y))
+		}
+	}
+	return out
+}
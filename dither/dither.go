@@ -43,26 +43,39 @@ func Dither(img image.Image, algo Algo, find ColorFinder) image.Image {
 	return dithered
 }
 
+// FloydSteinbergKernel is the Floyd-Steinberg error-diffusion kernel.
+var FloydSteinbergKernel = DiffusionKernel{
+	Offsets: []image.Point{
+		{1, 0},
+		{-1, 1}, {0, 1}, {1, 1},
+	},
+	Weights: []int{
+		7,
+		3, 5, 1,
+	},
+	Divisor: 16,
+}
+
 // FloydSteinberg applies Floyd-Steinber dithering to image.
 // Should not be called directly and should be rather passed Dither function.
-func FloydSteinberg(x, y int, img *image.RGBA, err RGBAVec) {
-	img.Set(x+1, y, applyWeightsFloydSteinber(img.At(x+1, y), err, 7))
-	img.Set(x+1, y+1, applyWeightsFloydSteinber(img.At(x+1, y+1), err, 1))
-	img.Set(x, y+1, applyWeightsFloydSteinber(img.At(x, y+1), err, 5))
-	img.Set(x-1, y+1, applyWeightsFloydSteinber(img.At(x-1, y+1), err, 3))
+var FloydSteinberg = NewDiffusionAlgo(FloydSteinbergKernel)
+
+// BurkesKernel is the Burkes error-diffusion kernel.
+var BurkesKernel = DiffusionKernel{
+	Offsets: []image.Point{
+		{1, 0}, {2, 0},
+		{-2, 1}, {-1, 1}, {0, 1}, {1, 1}, {2, 1},
+	},
+	Weights: []int{
+		4, 2,
+		1, 2, 4, 2, 1,
+	},
+	Divisor: 16,
 }
 
 // Burkes applies Burkes dithering to image.
 // Should not be called directly and should be rather passed Dither function.
-func Burkes(x, y int, img *image.RGBA, err RGBAVec) {
-	img.Set(x+1, y, applyWeightsBurkes(img.At(x+1, y), err, 3))
-	img.Set(x+2, y, applyWeightsBurkes(img.At(x+2, y), err, 2))
-	img.Set(x-2, y+1, applyWeightsBurkes(img.At(x-2, y+1), err, 1))
-	img.Set(x-1, y+1, applyWeightsBurkes(img.At(x-1, y+1), err, 2))
-	img.Set(x, y+1, applyWeightsBurkes(img.At(x, y+1), err, 3))
-	img.Set(x+1, y+1, applyWeightsBurkes(img.At(x+1, y+1), err, 2))
-	img.Set(x+2, y+1, applyWeightsBurkes(img.At(x+2, y+1), err, 1))
-}
+var Burkes = NewDiffusionAlgo(BurkesKernel)
 
 // BlackAndWhitePalette converts colors to either black or white.
 // RGB values are converted into grayscale with:
@@ -112,28 +125,6 @@ func makeColor(r, g, b, a int64) color.Color {
 	col.A = uint16(a)
 	return col
 }
-func applyWeightsFloydSteinber(orig color.Color, err RGBAVec, mul int) color.Color {
-	oR, oG, oB, oA := orig.RGBA()
-	var (
-		nR = int64(oR) + (err.R*int64(mul))>>4
-		nG = int64(oG) + (err.G*int64(mul))>>4
-		nB = int64(oB) + (err.B*int64(mul))>>4
-		nA = int64(oA) + (err.A*int64(mul))>>4
-	)
-	return makeColor(nR, nG, nB, nA)
-}
-
-func applyWeightsBurkes(orig color.Color, err RGBAVec, mul uint) color.Color {
-	oR, oG, oB, oA := orig.RGBA()
-	var (
-		nR = int64(oR) + (err.R<<uint64(mul))>>5
-		nG = int64(oG) + (err.G<<uint64(mul))>>5
-		nB = int64(oB) + (err.B<<uint64(mul))>>5
-		nA = int64(oA) + (err.A<<uint64(mul))>>5
-	)
-	return makeColor(nR, nG, nB, nA)
-}
-
 func colorDiff(a, b color.Color) (diff RGBAVec) {
 	aR, aG, aB, aA := a.RGBA()
 	bR, bG, bB, bA := b.RGBA()
@@ -0,0 +1,137 @@
+package dither
+
+import (
+	"image"
+	"image/color"
+)
+
+// DiffusionKernel describes an error-diffusion matrix: for each offset in
+// Offsets (relative to the pixel currently being quantized), the
+// corresponding entry in Weights is the fraction (Weights[i]/Divisor) of the
+// quantization error pushed onto that neighbor. Weights need not sum to
+// Divisor — Atkinson, for example, only propagates 6/8 of the error, which
+// is what gives it extra contrast.
+type DiffusionKernel struct {
+	Offsets []image.Point
+	Weights []int
+	Divisor int
+}
+
+// NewDiffusionAlgo builds an Algo that diffuses error according to k. Every
+// diffusion Algo in this package, FloydSteinberg and Burkes included, is
+// built with it, and callers can use it to define their own kernels.
+func NewDiffusionAlgo(k DiffusionKernel) Algo {
+	return func(x, y int, img *image.RGBA, err RGBAVec) {
+		for i, off := range k.Offsets {
+			nx, ny := x+off.X, y+off.Y
+			img.Set(nx, ny, applyWeightKernel(img.At(nx, ny), err, int64(k.Weights[i]), int64(k.Divisor)))
+		}
+	}
+}
+
+func applyWeightKernel(orig color.Color, err RGBAVec, weight, divisor int64) color.Color {
+	oR, oG, oB, oA := orig.RGBA()
+	var (
+		nR = int64(oR) + err.R*weight/divisor
+		nG = int64(oG) + err.G*weight/divisor
+		nB = int64(oB) + err.B*weight/divisor
+		nA = int64(oA) + err.A*weight/divisor
+	)
+	return makeColor(nR, nG, nB, nA)
+}
+
+// JarvisKernel is the Jarvis-Judice-Ninke error-diffusion kernel.
+var JarvisKernel = DiffusionKernel{
+	Offsets: []image.Point{
+		{1, 0}, {2, 0},
+		{-2, 1}, {-1, 1}, {0, 1}, {1, 1}, {2, 1},
+		{-2, 2}, {-1, 2}, {0, 2}, {1, 2}, {2, 2},
+	},
+	Weights: []int{
+		7, 5,
+		3, 5, 7, 5, 3,
+		1, 3, 5, 3, 1,
+	},
+	Divisor: 48,
+}
+
+// Jarvis applies Jarvis-Judice-Ninke dithering to image.
+// Should not be called directly and should be rather passed Dither function.
+var Jarvis = NewDiffusionAlgo(JarvisKernel)
+
+// StuckiKernel is the Stucki error-diffusion kernel.
+var StuckiKernel = DiffusionKernel{
+	Offsets: []image.Point{
+		{1, 0}, {2, 0},
+		{-2, 1}, {-1, 1}, {0, 1}, {1, 1}, {2, 1},
+		{-2, 2}, {-1, 2}, {0, 2}, {1, 2}, {2, 2},
+	},
+	Weights: []int{
+		8, 4,
+		2, 4, 8, 4, 2,
+		1, 2, 4, 2, 1,
+	},
+	Divisor: 42,
+}
+
+// Stucki applies Stucki dithering to image.
+// Should not be called directly and should be rather passed Dither function.
+var Stucki = NewDiffusionAlgo(StuckiKernel)
+
+// SierraKernel is the (full) Sierra error-diffusion kernel.
+var SierraKernel = DiffusionKernel{
+	Offsets: []image.Point{
+		{1, 0}, {2, 0},
+		{-2, 1}, {-1, 1}, {0, 1}, {1, 1}, {2, 1},
+		{-1, 2}, {0, 2}, {1, 2},
+	},
+	Weights: []int{
+		5, 3,
+		2, 4, 5, 4, 2,
+		2, 3, 2,
+	},
+	Divisor: 32,
+}
+
+// Sierra applies Sierra dithering to image.
+// Should not be called directly and should be rather passed Dither function.
+var Sierra = NewDiffusionAlgo(SierraKernel)
+
+// SierraLiteKernel is the Sierra-Lite (a.k.a. "Filter Lite") error-diffusion
+// kernel, a cheap two-row approximation of SierraKernel.
+var SierraLiteKernel = DiffusionKernel{
+	Offsets: []image.Point{
+		{1, 0},
+		{-1, 1}, {0, 1},
+	},
+	Weights: []int{
+		2,
+		1, 1,
+	},
+	Divisor: 4,
+}
+
+// SierraLite applies Sierra-Lite dithering to image.
+// Should not be called directly and should be rather passed Dither function.
+var SierraLite = NewDiffusionAlgo(SierraLiteKernel)
+
+// AtkinsonKernel is the Atkinson error-diffusion kernel. Its weights sum to
+// 6/8 rather than 8/8, so only three quarters of the quantization error is
+// propagated, which is what gives Atkinson dithering its higher contrast.
+var AtkinsonKernel = DiffusionKernel{
+	Offsets: []image.Point{
+		{1, 0}, {2, 0},
+		{-1, 1}, {0, 1}, {1, 1},
+		{0, 2},
+	},
+	Weights: []int{
+		1, 1,
+		1, 1, 1,
+		1,
+	},
+	Divisor: 8,
+}
+
+// Atkinson applies Atkinson dithering to image.
+// Should not be called directly and should be rather passed Dither function.
+var Atkinson = NewDiffusionAlgo(AtkinsonKernel)
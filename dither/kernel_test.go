@@ -0,0 +1,75 @@
+package dither
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestDiffusionKernelWeightsSumToDivisor(t *testing.T) {
+	kernels := map[string]DiffusionKernel{
+		"FloydSteinberg": FloydSteinbergKernel,
+		"Burkes":         BurkesKernel,
+		"Jarvis":         JarvisKernel,
+		"Stucki":         StuckiKernel,
+		"Sierra":         SierraKernel,
+		"SierraLite":     SierraLiteKernel,
+	}
+	for name, k := range kernels {
+		sum := 0
+		for _, w := range k.Weights {
+			sum += w
+		}
+		if sum != k.Divisor {
+			t.Errorf("%s: weights sum to %d, want %d (all quantization error should propagate)", name, sum, k.Divisor)
+		}
+	}
+}
+
+// TestAtkinsonKernelPropagatesThreeQuarters documents the one kernel that's
+// allowed to deviate from summing to its Divisor: Atkinson only propagates
+// 6/8 of the error, which is what gives it higher contrast.
+func TestAtkinsonKernelPropagatesThreeQuarters(t *testing.T) {
+	sum := 0
+	for _, w := range AtkinsonKernel.Weights {
+		sum += w
+	}
+	if got, want := sum*4, AtkinsonKernel.Divisor*3; got != want {
+		t.Errorf("Atkinson: weights sum to %d/%d, want 6/8 (3/4) of the error propagated", sum, AtkinsonKernel.Divisor)
+	}
+}
+
+// TestNewDiffusionAlgoPreservesUniformImages exercises every diffusion
+// kernel against images with no quantization error at all: a uniformly
+// black or white image should come back unchanged, regardless of kernel.
+func TestNewDiffusionAlgoPreservesUniformImages(t *testing.T) {
+	kernels := map[string]DiffusionKernel{
+		"FloydSteinberg": FloydSteinbergKernel,
+		"Burkes":         BurkesKernel,
+		"Jarvis":         JarvisKernel,
+		"Stucki":         StuckiKernel,
+		"Sierra":         SierraKernel,
+		"SierraLite":     SierraLiteKernel,
+		"Atkinson":       AtkinsonKernel,
+	}
+	for name, k := range kernels {
+		for _, gray := range []uint16{0, 0xFFFF} {
+			img := image.NewGray16(image.Rect(0, 0, 5, 5))
+			for y := 0; y < 5; y++ {
+				for x := 0; x < 5; x++ {
+					img.SetGray16(x, y, color.Gray16{Y: gray})
+				}
+			}
+			out := Dither(img, NewDiffusionAlgo(k), BlackAndWhitePalette)
+			for y := 0; y < 5; y++ {
+				for x := 0; x < 5; x++ {
+					wr, wg, wb, _ := img.At(x, y).RGBA()
+					gr, gg, gb, _ := out.At(x, y).RGBA()
+					if wr != gr || wg != gg || wb != gb {
+						t.Fatalf("%s: pixel (%d,%d) changed on uniform gray=%#x input", name, x, y, gray)
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package dither
+
+import (
+	"image"
+	"image/color"
+	"sort"
+)
+
+// mcBucket is a set of pixels being subdivided by MedianCut.
+type mcBucket struct {
+	pixels []color.NRGBA
+}
+
+// rangeAndAxis returns which of R/G/B has the largest value range in the
+// bucket, along with that range, so MedianCut can pick the split axis.
+func (b mcBucket) rangeAndAxis() (axis int, span uint8) {
+	var min, max [3]uint8
+	min = [3]uint8{255, 255, 255}
+	for _, p := range b.pixels {
+		c := [3]uint8{p.R, p.G, p.B}
+		for i := 0; i < 3; i++ {
+			if c[i] < min[i] {
+				min[i] = c[i]
+			}
+			if c[i] > max[i] {
+				max[i] = c[i]
+			}
+		}
+	}
+	axis = 0
+	span = max[0] - min[0]
+	for i := 1; i < 3; i++ {
+		if s := max[i] - min[i]; s > span {
+			axis = i
+			span = s
+		}
+	}
+	return axis, span
+}
+
+func (b mcBucket) mean() color.Color {
+	var r, g, bl int
+	for _, p := range b.pixels {
+		r += int(p.R)
+		g += int(p.G)
+		bl += int(p.B)
+	}
+	n := len(b.pixels)
+	return color.NRGBA{
+		R: uint8(r / n),
+		G: uint8(g / n),
+		B: uint8(bl / n),
+		A: 0xFF,
+	}
+}
+
+// MedianCut derives an n-color palette (n a power of two) from img using the
+// median-cut algorithm: starting from one bucket containing every pixel, it
+// repeatedly splits the bucket with the largest per-channel range at the
+// median along that channel until there are n buckets, then emits the mean
+// color of each bucket. A bucket of a single pixel is never split, so if img
+// has fewer than n pixels the palette has one color per pixel rather than n
+// colors. The result is usable directly with PaletteFinder or
+// PaletteFinderLab, e.g. for GIF export or hand-picked retro palettes.
+func MedianCut(img image.Image, n int) color.Palette {
+	rect := img.Bounds()
+	pixels := make([]color.NRGBA, 0, rect.Dx()*rect.Dy())
+	for y := rect.Min.Y; y < rect.Max.Y; y++ {
+		for x := rect.Min.X; x < rect.Max.X; x++ {
+			r, g, b, a := img.At(x, y).RGBA()
+			pixels = append(pixels, color.NRGBA{
+				R: uint8(r >> 8),
+				G: uint8(g >> 8),
+				B: uint8(b >> 8),
+				A: uint8(a >> 8),
+			})
+		}
+	}
+
+	buckets := []mcBucket{{pixels: pixels}}
+	for len(buckets) < n {
+		splitIdx, splitAxis, bestSpan := -1, 0, -1
+		for i, b := range buckets {
+			if len(b.pixels) < 2 {
+				continue
+			}
+			axis, span := b.rangeAndAxis()
+			if int(span) > bestSpan {
+				splitIdx, splitAxis, bestSpan = i, axis, int(span)
+			}
+		}
+		if splitIdx == -1 {
+			// No bucket left that can usefully be split further.
+			break
+		}
+		b := buckets[splitIdx]
+		sort.Slice(b.pixels, func(i, j int) bool {
+			return channel(b.pixels[i], splitAxis) < channel(b.pixels[j], splitAxis)
+		})
+		mid := len(b.pixels) / 2
+		left := mcBucket{pixels: b.pixels[:mid]}
+		right := mcBucket{pixels: b.pixels[mid:]}
+		buckets[splitIdx] = left
+		buckets = append(buckets, right)
+	}
+
+	p := make(color.Palette, len(buckets))
+	for i, b := range buckets {
+		p[i] = b.mean()
+	}
+	return p
+}
+
+func channel(c color.NRGBA, axis int) uint8 {
+	switch axis {
+	case 0:
+		return c.R
+	case 1:
+		return c.G
+	default:
+		return c.B
+	}
+}
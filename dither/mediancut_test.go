@@ -0,0 +1,59 @@
+package dither
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestMedianCutProducesRequestedSizeAndCoversClusters(t *testing.T) {
+	// Two widely separated, solid-color halves: median-cut on 2 colors
+	// should recover both exactly, since each bucket's mean is just that
+	// solid color.
+	img := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	left := color.RGBA{10, 10, 10, 255}
+	right := color.RGBA{240, 240, 240, 255}
+	for y := 0; y < 4; y++ {
+		for x := 0; x < 4; x++ {
+			if x < 2 {
+				img.Set(x, y, left)
+			} else {
+				img.Set(x, y, right)
+			}
+		}
+	}
+
+	p := MedianCut(img, 2)
+	if len(p) != 2 {
+		t.Fatalf("MedianCut(img, 2) returned %d colors, want 2", len(p))
+	}
+
+	foundLeft, foundRight := false, false
+	for _, c := range p {
+		r, g, b, _ := c.RGBA()
+		switch {
+		case r>>8 == 10 && g>>8 == 10 && b>>8 == 10:
+			foundLeft = true
+		case r>>8 == 240 && g>>8 == 240 && b>>8 == 240:
+			foundRight = true
+		}
+	}
+	if !foundLeft || !foundRight {
+		t.Errorf("MedianCut(img, 2) = %v, want buckets recovering %v and %v", p, left, right)
+	}
+}
+
+func TestMedianCutIsUsableWithPaletteFinder(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 8, 8))
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 8; x++ {
+			img.Set(x, y, color.RGBA{uint8(x * 32), uint8(y * 32), 0, 255})
+		}
+	}
+	p := MedianCut(img, 4)
+	find := PaletteFinder(p)
+	out := Dither(img, FloydSteinberg, find)
+	if out.Bounds() != img.Bounds() {
+		t.Errorf("Dither with MedianCut palette changed bounds: got %v, want %v", out.Bounds(), img.Bounds())
+	}
+}
@@ -0,0 +1,98 @@
+package dither
+
+import (
+	"image"
+	"image/draw"
+)
+
+// Drawer mirrors image/draw.Drawer: anything that can turn a source image into
+// a dithered one given a ColorFinder. FloydSteinberg/Burkes-style error
+// diffusion and the ordered (threshold-map) dithering below both satisfy it,
+// even though they distribute quantization error very differently.
+type Drawer interface {
+	Draw(img image.Image, find ColorFinder) image.Image
+}
+
+// DiffusionDrawer adapts an Algo into a Drawer by running it through Dither.
+type DiffusionDrawer struct {
+	Algo Algo
+}
+
+// Draw implements Drawer.
+func (d DiffusionDrawer) Draw(img image.Image, find ColorFinder) image.Image {
+	return Dither(img, d.Algo, find)
+}
+
+// OrderedDrawer implements ordered dithering using a threshold map (e.g. one
+// of the Bayer matrices below). Unlike error diffusion it is a single pass
+// over the image and doesn't depend on neighboring pixels, which makes it
+// trivially parallelizable.
+type OrderedDrawer struct {
+	Matrix [][]int
+}
+
+// Draw implements Drawer.
+func (o OrderedDrawer) Draw(img image.Image, find ColorFinder) image.Image {
+	return Ordered(img, o.Matrix, find)
+}
+
+// orderedScale controls how much the threshold map perturbs a pixel before
+// quantization. It's expressed in the same [0, 0xFFFF] range as color.Color
+// channels; the bias lies in [-0.5, 0.5) * orderedScale, so it moves a
+// channel by at most half of its full range in either direction.
+const orderedScale = 0xFFFF
+
+// Ordered applies ordered (threshold-map) dithering to image, using matrix as
+// the per-pixel bias map. matrix is tiled across the image: for an n x n
+// matrix, pixel (x, y) is biased by
+//
+//	bias = (matrix[y%n][x%n]/n^2 - 0.5) * orderedScale
+//
+// before find quantizes it. OrderedDrawer wraps it as a Drawer.
+func Ordered(img image.Image, matrix [][]int, find ColorFinder) image.Image {
+	rect := img.Bounds()
+	dithered := image.NewRGBA(rect)
+	draw.Draw(dithered, rect, img, rect.Min, draw.Src)
+
+	n := len(matrix)
+	n2 := float64(n * n)
+	for y := rect.Min.Y; y < rect.Max.Y; y++ {
+		for x := rect.Min.X; x < rect.Max.X; x++ {
+			bias := (float64(matrix[y%n][x%n])/n2 - 0.5) * orderedScale
+			oldPixel := dithered.At(x, y)
+			oR, oG, oB, oA := oldPixel.RGBA()
+			biased := makeColor(int64(oR)+int64(bias), int64(oG)+int64(bias), int64(oB)+int64(bias), int64(oA))
+			dithered.Set(x, y, find(biased))
+		}
+	}
+	return dithered
+}
+
+// expandBayer builds the 2n x 2n Bayer matrix from the n x n matrix m using
+// the standard recurrence M_{2n} = [[4M_n, 4M_n+2], [4M_n+3, 4M_n+1]].
+func expandBayer(m [][]int) [][]int {
+	n := len(m)
+	out := make([][]int, n*2)
+	for i := range out {
+		out[i] = make([]int, n*2)
+	}
+	for y := 0; y < n; y++ {
+		for x := 0; x < n; x++ {
+			v := m[y][x]
+			out[y][x] = 4 * v
+			out[y][x+n] = 4*v + 2
+			out[y+n][x] = 4*v + 3
+			out[y+n][x+n] = 4*v + 1
+		}
+	}
+	return out
+}
+
+// Bayer2, Bayer4 and Bayer8 are the standard 2x2, 4x4 and 8x8 Bayer threshold
+// maps, generated from M_1 = [[0]] via the recurrence documented on
+// expandBayer.
+var (
+	Bayer2 = expandBayer([][]int{{0}})
+	Bayer4 = expandBayer(Bayer2)
+	Bayer8 = expandBayer(Bayer4)
+)
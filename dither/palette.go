@@ -0,0 +1,97 @@
+package dither
+
+import (
+	"image/color"
+	"math"
+)
+
+// PaletteFinder returns a ColorFinder that quantizes to the nearest color in
+// p, using the same nearest-neighbor semantics as color.Palette.Convert.
+func PaletteFinder(p color.Palette) ColorFinder {
+	return func(c color.Color) color.Color {
+		return p.Convert(c)
+	}
+}
+
+// labColor is a color's CIELAB coordinates, used by PaletteFinderLab for
+// perceptual nearest-neighbor search.
+type labColor struct {
+	L, A, B float64
+}
+
+// PaletteFinderLab returns a ColorFinder that quantizes to the nearest color
+// in p by CIELAB (ΔE) distance rather than Euclidean RGB distance, which
+// tracks human color perception much more closely on gradients. The Lab
+// coordinates of every palette entry are precomputed once, so each pixel
+// costs O(len(p)) distance evaluations.
+func PaletteFinderLab(p color.Palette) ColorFinder {
+	labs := make([]labColor, len(p))
+	for i, c := range p {
+		labs[i] = colorToLab(c)
+	}
+	return func(c color.Color) color.Color {
+		target := colorToLab(c)
+		best := 0
+		bestDist := math.MaxFloat64
+		for i, lab := range labs {
+			dist := labDistSq(target, lab)
+			if dist < bestDist {
+				bestDist = dist
+				best = i
+			}
+		}
+		return p[best]
+	}
+}
+
+// colorToLab converts c (sRGB) into CIELAB via linear RGB and CIE XYZ (D65
+// white point).
+func colorToLab(c color.Color) labColor {
+	r, g, b, _ := c.RGBA()
+	lr := srgbToLinear(float64(r) / 0xFFFF)
+	lg := srgbToLinear(float64(g) / 0xFFFF)
+	lb := srgbToLinear(float64(b) / 0xFFFF)
+
+	// sRGB -> XYZ (D65), see https://en.wikipedia.org/wiki/SRGB
+	x := lr*0.4124564 + lg*0.3575761 + lb*0.1804375
+	y := lr*0.2126729 + lg*0.7151522 + lb*0.0721750
+	z := lr*0.0193339 + lg*0.1191920 + lb*0.9503041
+
+	// D65 reference white.
+	const (
+		xn = 0.95047
+		yn = 1.00000
+		zn = 1.08883
+	)
+	fx := labF(x / xn)
+	fy := labF(y / yn)
+	fz := labF(z / zn)
+
+	return labColor{
+		L: 116*fy - 16,
+		A: 500 * (fx - fy),
+		B: 200 * (fy - fz),
+	}
+}
+
+func srgbToLinear(v float64) float64 {
+	if v <= 0.04045 {
+		return v / 12.92
+	}
+	return math.Pow((v+0.055)/1.055, 2.4)
+}
+
+func labF(t float64) float64 {
+	const delta = 6.0 / 29.0
+	if t > delta*delta*delta {
+		return math.Cbrt(t)
+	}
+	return t/(3*delta*delta) + 4.0/29.0
+}
+
+func labDistSq(a, b labColor) float64 {
+	dl := a.L - b.L
+	da := a.A - b.A
+	db := a.B - b.B
+	return dl*dl + da*da + db*db
+}
@@ -0,0 +1,54 @@
+package dither
+
+import (
+	"image/color"
+	"math"
+	"testing"
+)
+
+func TestPaletteFinderReturnsExactMatch(t *testing.T) {
+	p := color.Palette{color.Black, color.White, color.RGBA{255, 0, 0, 255}}
+	find := PaletteFinder(p)
+	for _, c := range p {
+		if got := find(c); got != c {
+			t.Errorf("PaletteFinder(%v) = %v, want exact match", c, got)
+		}
+	}
+}
+
+func TestColorToLabMatchesKnownReferenceValues(t *testing.T) {
+	cases := []struct {
+		name    string
+		c       color.Color
+		wantL   float64
+		wantTol float64
+	}{
+		{"black", color.Black, 0, 0.5},
+		{"white", color.White, 100, 0.5},
+	}
+	for _, tc := range cases {
+		lab := colorToLab(tc.c)
+		if math.Abs(lab.L-tc.wantL) > tc.wantTol {
+			t.Errorf("%s: L* = %.2f, want ~%.2f", tc.name, lab.L, tc.wantL)
+		}
+	}
+}
+
+func TestPaletteFinderLabPicksNearestByDeltaE(t *testing.T) {
+	red := color.RGBA{255, 0, 0, 255}
+	green := color.RGBA{0, 255, 0, 255}
+	blue := color.RGBA{0, 0, 255, 255}
+	p := color.Palette{red, green, blue}
+	find := PaletteFinderLab(p)
+
+	// An exact palette entry must map to itself.
+	if got := find(red); got != color.Color(red) {
+		t.Errorf("PaletteFinderLab(red) = %v, want %v", got, red)
+	}
+
+	// A color much closer to red than to green or blue should still pick red.
+	nearRed := color.RGBA{235, 20, 10, 255}
+	if got := find(nearRed); got != color.Color(red) {
+		t.Errorf("PaletteFinderLab(%v) = %v, want %v", nearRed, got, red)
+	}
+}
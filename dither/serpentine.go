@@ -0,0 +1,50 @@
+package dither
+
+import (
+	"image"
+	"image/draw"
+)
+
+// DitherSerpentine applies error-diffusion dithering in serpentine
+// (boustrophedon) scan order: even rows are traversed left-to-right and odd
+// rows right-to-left, mirroring k's x-offsets on the reverse rows. This
+// eliminates the diagonal "worm" artifacts that plain left-to-right
+// diffusion produces on smooth gradients.
+//
+// It takes a DiffusionKernel rather than an Algo because mirroring requires
+// access to the kernel's offsets, which a plain Algo closure hides.
+func DitherSerpentine(img image.Image, k DiffusionKernel, find ColorFinder) image.Image {
+	rect := img.Bounds()
+	dithered := image.NewRGBA(rect)
+	draw.Draw(dithered, rect, img, rect.Min, draw.Src)
+
+	for y := rect.Min.Y; y < rect.Max.Y; y++ {
+		reverse := (y-rect.Min.Y)%2 == 1
+		if reverse {
+			for x := rect.Max.X - 1; x >= rect.Min.X; x-- {
+				diffuseSerpentine(dithered, k, x, y, find, true)
+			}
+		} else {
+			for x := rect.Min.X; x < rect.Max.X; x++ {
+				diffuseSerpentine(dithered, k, x, y, find, false)
+			}
+		}
+	}
+	return dithered
+}
+
+func diffuseSerpentine(img *image.RGBA, k DiffusionKernel, x, y int, find ColorFinder, mirror bool) {
+	oldPixel := img.At(x, y)
+	newPixel := find(oldPixel)
+	img.Set(x, y, newPixel)
+	diff := colorDiff(oldPixel, newPixel)
+
+	for i, off := range k.Offsets {
+		dx := off.X
+		if mirror {
+			dx = -dx
+		}
+		nx, ny := x+dx, y+off.Y
+		img.Set(nx, ny, applyWeightKernel(img.At(nx, ny), diff, int64(k.Weights[i]), int64(k.Divisor)))
+	}
+}
@@ -0,0 +1,89 @@
+package dither
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+// ditherRowLTR is an independent, single-row reference implementation of
+// error diffusion (left-to-right, no mirroring), used below to check that
+// DitherSerpentine's reversed-row handling is exactly "process the row
+// backwards with the kernel mirrored", rather than comparing against the
+// package's own DitherSerpentine/Dither code under test.
+func ditherRowLTR(row []color.Color, k DiffusionKernel, find ColorFinder) []color.Color {
+	vals := make([]color.Color, len(row))
+	copy(vals, row)
+	for x := range vals {
+		old := vals[x]
+		newC := find(old)
+		diff := colorDiff(old, newC)
+		vals[x] = newC
+		for i, off := range k.Offsets {
+			if off.Y != 0 {
+				continue
+			}
+			nx := x + off.X
+			if nx < 0 || nx >= len(vals) {
+				continue
+			}
+			vals[nx] = applyWeightKernel(vals[nx], diff, int64(k.Weights[i]), int64(k.Divisor))
+		}
+	}
+	return vals
+}
+
+func rowColors(img image.Image, y, width int) []color.Color {
+	row := make([]color.Color, width)
+	for x := 0; x < width; x++ {
+		row[x] = img.At(x, y)
+	}
+	return row
+}
+
+func reverseColors(row []color.Color) []color.Color {
+	out := make([]color.Color, len(row))
+	for i, c := range row {
+		out[len(row)-1-i] = c
+	}
+	return out
+}
+
+func assertRowEqual(t *testing.T, img image.Image, y int, want []color.Color) {
+	t.Helper()
+	for x, wc := range want {
+		wr, wg, wb, _ := wc.RGBA()
+		gr, gg, gb, _ := img.At(x, y).RGBA()
+		if wr != gr || wg != gg || wb != gb {
+			t.Errorf("row %d, pixel %d: got %v, want %v", y, x, img.At(x, y), wc)
+		}
+	}
+}
+
+// TestDitherSerpentineMirrorsKernelOnOddRows checks that odd rows are
+// diffused as if the row were reversed, dithered left-to-right with the
+// unmirrored kernel, then reversed back — which is what mirroring the
+// kernel's x-offsets on a right-to-left pass should amount to.
+func TestDitherSerpentineMirrorsKernelOnOddRows(t *testing.T) {
+	k := DiffusionKernel{
+		Offsets: []image.Point{{1, 0}},
+		Weights: []int{1},
+		Divisor: 1,
+	}
+	w, h := 6, 2
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, color.Gray{Y: uint8((x * 43) % 256)})
+		}
+	}
+
+	out := DitherSerpentine(img, k, BlackAndWhitePalette)
+
+	wantRow0 := ditherRowLTR(rowColors(img, 0, w), k, BlackAndWhitePalette)
+	assertRowEqual(t, out, 0, wantRow0)
+
+	reversed := reverseColors(rowColors(img, 1, w))
+	wantRow1 := reverseColors(ditherRowLTR(reversed, k, BlackAndWhitePalette))
+	assertRowEqual(t, out, 1, wantRow1)
+}
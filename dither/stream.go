@@ -0,0 +1,149 @@
+package dither
+
+import (
+	"bufio"
+	"fmt"
+	"image/color"
+	"io"
+)
+
+// StreamOpts configures a Stream.
+type StreamOpts struct {
+	// Serpentine mirrors the kernel horizontally on odd rows, same as
+	// DitherSerpentine.
+	Serpentine bool
+}
+
+// Stream performs error-diffusion dithering one scanline at a time. Memory
+// use is bounded by the kernel's vertical reach (the error accumulator) plus
+// one scanline (the row being written out) — the whole image is never held
+// in memory, which is what makes dithering multi-gigapixel scans feasible.
+//
+// Because there is no way to emit scanlines incrementally in most standard
+// image codecs, Feed writes each completed scanline as part of a flat,
+// headerless stream of 8-bit RGB triples (width*height*3 bytes in all,
+// row-major) rather than PNG, and Close flushes what is still buffered;
+// callers that need PNG/GIF/JPEG have to re-encode that raw stream
+// themselves once it's off the wire, at whatever point they can afford to
+// hold the full image again.
+//
+// Stream's accumulated error is not clamped to the valid channel range until
+// a pixel is quantized, whereas Dither clamps every diffused contribution as
+// soon as it's written. This means Stream can produce different output than
+// Dither with the same kernel on images with saturated (clipped) regions,
+// even with Serpentine: false. This is a deliberate tradeoff: matching
+// Dither's per-contribution clamping exactly would require knowing a
+// destination row's source pixels before it's diffused into, which isn't
+// possible until that row is fed.
+type Stream struct {
+	w      *bufio.Writer
+	kernel DiffusionKernel
+	find   ColorFinder
+	opts   StreamOpts
+	width  int
+	reach  int
+
+	// errRows is a ring buffer of reach+1 rows of pending error, indexed as
+	// errRows[y%len(errRows)][x].
+	errRows [][]RGBAVec
+
+	// rowBuf holds one scanline of quantized pixels in left-to-right order,
+	// reused across Feed calls, before it's flushed to w.
+	rowBuf []color.Color
+
+	y int
+}
+
+// NewStream creates a Stream that dithers an image of the given width using
+// the error-diffusion kernel k and find, writing quantized scanlines to w as
+// Feed completes them. height is unused by Stream itself — it isn't needed
+// to bound memory — and is kept only so callers have a natural place to
+// record the dimensions of the raw stream written to w.
+func NewStream(w io.Writer, k DiffusionKernel, find ColorFinder, width, height int, opts StreamOpts) *Stream {
+	reach := kernelVerticalReach(k)
+	numRows := reach + 1
+	errRows := make([][]RGBAVec, numRows)
+	for i := range errRows {
+		errRows[i] = make([]RGBAVec, width)
+	}
+	return &Stream{
+		w:       bufio.NewWriter(w),
+		kernel:  k,
+		find:    find,
+		opts:    opts,
+		width:   width,
+		reach:   reach,
+		errRows: errRows,
+		rowBuf:  make([]color.Color, width),
+	}
+}
+
+// kernelVerticalReach returns how many rows below the current one k can
+// still diffuse error into.
+func kernelVerticalReach(k DiffusionKernel) int {
+	reach := 0
+	for _, off := range k.Offsets {
+		if off.Y > reach {
+			reach = off.Y
+		}
+	}
+	return reach
+}
+
+// Feed dithers one scanline of source pixels and writes it to the
+// underlying writer. Rows must be fed in order, top to bottom.
+func (s *Stream) Feed(row []color.Color) error {
+	if len(row) != s.width {
+		return fmt.Errorf("dither: row has %d pixels, stream width is %d", len(row), s.width)
+	}
+
+	reverse := s.opts.Serpentine && s.y%2 == 1
+	curRow := s.errRows[s.y%len(s.errRows)]
+
+	for i := 0; i < s.width; i++ {
+		x := i
+		if reverse {
+			x = s.width - 1 - i
+		}
+
+		acc := curRow[x]
+		oR, oG, oB, oA := row[x].RGBA()
+		biased := makeColor(int64(oR)+acc.R, int64(oG)+acc.G, int64(oB)+acc.B, int64(oA)+acc.A)
+		newPixel := s.find(biased)
+		s.rowBuf[x] = newPixel
+		curRow[x] = RGBAVec{}
+
+		diff := colorDiff(biased, newPixel)
+		for k, off := range s.kernel.Offsets {
+			dx := off.X
+			if reverse {
+				dx = -dx
+			}
+			nx := x + dx
+			if nx < 0 || nx >= s.width {
+				continue
+			}
+			weight := int64(s.kernel.Weights[k])
+			target := &s.errRows[(s.y+off.Y)%len(s.errRows)][nx]
+			target.R += diff.R * weight / int64(s.kernel.Divisor)
+			target.G += diff.G * weight / int64(s.kernel.Divisor)
+			target.B += diff.B * weight / int64(s.kernel.Divisor)
+			target.A += diff.A * weight / int64(s.kernel.Divisor)
+		}
+	}
+
+	for _, c := range s.rowBuf {
+		r, g, b, _ := c.RGBA()
+		if _, err := s.w.Write([]byte{byte(r >> 8), byte(g >> 8), byte(b >> 8)}); err != nil {
+			return fmt.Errorf("dither: failed to write row %d: %w", s.y, err)
+		}
+	}
+
+	s.y++
+	return nil
+}
+
+// Close flushes any buffered output to the underlying writer.
+func (s *Stream) Close() error {
+	return s.w.Flush()
+}
@@ -0,0 +1,69 @@
+package dither
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"testing"
+)
+
+// TestStreamMatchesDitherForNonOverlappingKernel uses a kernel whose single
+// offset ({0,1}, straight down) never lets two different source pixels
+// diffuse into the same destination pixel. That makes Stream's
+// accumulate-then-clamp and Dither's clamp-per-contribution coincide
+// exactly, so comparing them here is also a regression test for the ring
+// buffer's row indexing (errRows[(y+off.Y)%len(errRows)]) across multiple
+// rows.
+func TestStreamMatchesDitherForNonOverlappingKernel(t *testing.T) {
+	k := DiffusionKernel{
+		Offsets: []image.Point{{0, 1}},
+		Weights: []int{1},
+		Divisor: 1,
+	}
+	w, h := 6, 4
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, color.Gray{Y: uint8((x*37 + y*11) % 256)})
+		}
+	}
+
+	want := Dither(img, NewDiffusionAlgo(k), BlackAndWhitePalette)
+
+	var buf bytes.Buffer
+	s := NewStream(&buf, k, BlackAndWhitePalette, w, h, StreamOpts{})
+	for y := 0; y < h; y++ {
+		if err := s.Feed(rowColors(img, y, w)); err != nil {
+			t.Fatalf("Feed row %d: %v", y, err)
+		}
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	got := buf.Bytes()
+	if len(got) != w*h*3 {
+		t.Fatalf("got %d bytes, want %d", len(got), w*h*3)
+	}
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			wr, wg, wb, _ := want.At(x, y).RGBA()
+			off := (y*w + x) * 3
+			// Stream's raw output is 8-bit per channel, so truncate
+			// Dither's 16-bit result the same way before comparing.
+			wr8, wg8, wb8 := uint32(wr>>8), uint32(wg>>8), uint32(wb>>8)
+			gr, gg, gb := uint32(got[off]), uint32(got[off+1]), uint32(got[off+2])
+			if wr8 != gr || wg8 != gg || wb8 != gb {
+				t.Fatalf("pixel (%d,%d): Dither=(%d,%d,%d) Stream=(%d,%d,%d)", x, y, wr8, wg8, wb8, gr, gg, gb)
+			}
+		}
+	}
+}
+
+func TestStreamFeedRejectsWrongRowLength(t *testing.T) {
+	var buf bytes.Buffer
+	s := NewStream(&buf, FloydSteinbergKernel, BlackAndWhitePalette, 4, 2, StreamOpts{})
+	if err := s.Feed(make([]color.Color, 3)); err == nil {
+		t.Error("Feed with wrong row length: got nil error, want error")
+	}
+}